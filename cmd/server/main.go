@@ -3,18 +3,27 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"net/http/pprof"
 	"os"
 	"os/signal"
-	"sync/atomic"
 	"syscall"
 	"time"
 
-	"go.opentelemetry.io/otel"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/sync/errgroup"
+
 	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Alzgaymer/k8s-test/internal/config"
+	"github.com/Alzgaymer/k8s-test/internal/health"
+	"github.com/Alzgaymer/k8s-test/internal/metrics"
+	"github.com/Alzgaymer/k8s-test/internal/middleware"
+	"github.com/Alzgaymer/k8s-test/pkg/tracing"
 )
 
 const (
@@ -37,60 +46,147 @@ func main() {
 
 	slog.Info("Running server", gitLogGroup)
 
+	cfg, err := config.New()
+	if err != nil {
+		slog.Error("Failed to load config", "err", err)
+		os.Exit(1)
+	}
+
 	rootCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
 	ongoingCtx, stopOngoingGracefully := context.WithCancel(context.Background())
 
-	h, unaliveServer, cleanup := NewRoutes(ongoingCtx)
-	s := NewServer(ongoingCtx, os.Getenv("HOST"), os.Getenv("PORT"), h)
+	publicHandler, adminHandler, unaliveServer, cleanup, err := NewRoutes(ongoingCtx, cfg)
+	if err != nil {
+		slog.Error("Failed to build routes", "err", err)
+		os.Exit(1)
+	}
+
+	publicServer := NewServer(ongoingCtx, os.Getenv("HOST"), os.Getenv("PORT"), publicHandler)
+	adminServer := NewServer(ongoingCtx, os.Getenv("ADMIN_HOST"), os.Getenv("ADMIN_PORT"), adminHandler)
 
-	go func() {
-		slog.Info("Starting server", slog.String("addr", s.Addr))
+	// g's derived context is canceled both by rootCtx (SIGINT/SIGTERM) and
+	// by either listener returning an error, so a bind failure triggers
+	// shutdown immediately instead of hanging until a signal arrives.
+	g, gCtx := errgroup.WithContext(rootCtx)
 
-		if err := s.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			panic(err)
+	g.Go(func() error {
+		slog.Info("Starting public server", slog.String("addr", publicServer.Addr))
+		if err := publicServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("public server: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		slog.Info("Starting admin server", slog.String("addr", adminServer.Addr))
+		if err := adminServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("admin server: %w", err)
+		}
+		return nil
+	})
+
+	g.Go(func() error {
+		<-gCtx.Done()
+		// stop() cancels rootCtx, so record whether a signal arrived first.
+		signaled := rootCtx.Err() != nil
+		stop()
+		unaliveServer()
+
+		if !signaled {
+			slog.Error("A listener failed to start. Shutting down.")
+		} else {
+			slog.Info("Received signal. Shutting down.")
+			time.Sleep(_readinessDrainDelay)
+			slog.Info("Readiness check propagated, now waiting for ongoing requests to finish.")
 		}
-	}()
 
-	<-rootCtx.Done()
-	stop()
-	unaliveServer()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), _shutdownPeriod)
+		defer cancel()
 
-	slog.Info("Received signal. Shutting down.")
+		if err := publicServer.Shutdown(shutdownCtx); err != nil {
+			slog.Error("Failed to wait for public server's ongoing requests to finish, waiting for forced cancellation.")
+			time.Sleep(_shutdownHardPeriod)
+		}
 
-	time.Sleep(_readinessDrainDelay)
+		if err := adminServer.Shutdown(shutdownCtx); err != nil {
+			slog.Error("Failed to shut down admin server", "err", err)
+		}
 
-	slog.Info("Readiness check propagated, now waiting for ongoing requests to finish.")
+		stopOngoingGracefully()
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), _shutdownPeriod)
-	defer cancel()
+		slog.Info("Server shut down gracefully")
 
-	err := s.Shutdown(shutdownCtx)
-	stopOngoingGracefully()
+		slog.Info("Cleaning up dependencies...")
+		cleanup(shutdownCtx)
+
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		slog.Error("Server error", "err", err)
+		os.Exit(1)
+	}
+}
+
+// NewRoutes builds the public handler (business traffic) and the admin
+// handler (liveness, readiness, metrics, pprof), kept on separate mux
+// instances so introspection endpoints are never reachable through the
+// public listener.
+func NewRoutes(ctx context.Context, cfg config.AppConfig) (publicHandler, adminHandler http.Handler, unaliceServer func(), cleanup func(context.Context), err error) {
+	tp, cleanupTracer, err := tracing.Init(ctx, cfg.Tracing)
 	if err != nil {
-		slog.Error("Failed to wait for ongoing requests to finish, waiting for forced cancellation.")
-		time.Sleep(_shutdownHardPeriod)
+		return nil, nil, nil, nil, fmt.Errorf("new routes: %w", err)
 	}
 
-	slog.Info("Server shut down gracefully")
+	meterProvider, recorder, metricsHandler, err := metrics.Init()
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("new routes: %w", err)
+	}
 
-	slog.Info("Cleaning up dependencies...")
-	cleanup(shutdownCtx)
-}
+	checker := health.NewChecker(tp.Tracer("http.handler.health"))
 
-func NewRoutes(ctx context.Context) (handler http.Handler, unaliceServer func(), cleanup func(context.Context)) {
-	cleanupTracer := newTracer(ctx)
-	tp := otel.GetTracerProvider()
-	mux := http.NewServeMux()
+	var pool *pgxpool.Pool
+	if dsn := cfg.Database.DSN; dsn != "" {
+		pool, err = tracing.NewPGXPool(ctx, dsn)
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("new routes: %w", err)
+		}
+		checker.RegisterReadiness("database", pool.Ping)
+	}
 
-	rh := NewReadinessHandler(tp.Tracer("http.handler.readiness"))
+	pipelineFor := func(route string) middleware.Pipeline {
+		return middleware.New(
+			middleware.Tracing(tp.Tracer("http.handler.health"), route),
+			middleware.Metrics(recorder, route),
+			middleware.RequestID(),
+			middleware.AccessLog(slog.Default()),
+			middleware.Recover(),
+		)
+	}
 
-	mux.Handle("GET /health", rh)
+	publicMux := http.NewServeMux()
 
-	go produceTraces(ctx, tp.Tracer("dummy-trace-generator"))
+	adminMux := http.NewServeMux()
 
-	return mux, rh.MakeUnavailable, func(ctx context.Context) {
+	const livezRoute = "/livez"
+	adminMux.Handle("GET "+livezRoute, pipelineFor(livezRoute).Then(checker.LivenessHandler()))
+
+	const readyzRoute = "/readyz"
+	adminMux.Handle("GET "+readyzRoute, pipelineFor(readyzRoute).Then(checker.ReadinessHandler()))
+
+	adminMux.Handle("GET /metrics", metricsHandler)
+
+	adminMux.HandleFunc("GET /debug/pprof/", pprof.Index)
+	adminMux.HandleFunc("GET /debug/pprof/cmdline", pprof.Cmdline)
+	adminMux.HandleFunc("GET /debug/pprof/profile", pprof.Profile)
+	adminMux.HandleFunc("GET /debug/pprof/symbol", pprof.Symbol)
+	adminMux.HandleFunc("GET /debug/pprof/trace", pprof.Trace)
+
+	selfURL := fmt.Sprintf("http://localhost:%s"+livezRoute, os.Getenv("ADMIN_PORT"))
+	go produceTraces(ctx, tp.Tracer("dummy-trace-generator"), tracing.NewHTTPClient(), selfURL, pool)
+
+	return publicMux, adminMux, checker.MakeUnavailable, func(ctx context.Context) {
 		logError := func(msg string, err error) {
 			status := " succeeded"
 			if err != nil {
@@ -99,9 +195,12 @@ func NewRoutes(ctx context.Context) (handler http.Handler, unaliceServer func(),
 			slog.Error(msg+status, "err", err)
 		}
 
-		err := cleanupTracer(ctx)
-		logError("cleanup tracer", err)
-	}
+		logError("cleanup tracer", cleanupTracer(ctx))
+		logError("cleanup meter provider", meterProvider.Shutdown(ctx))
+		if pool != nil {
+			pool.Close()
+		}
+	}, nil
 }
 
 func NewServer(embedCtx context.Context, host, port string, handler http.Handler) *http.Server {
@@ -114,44 +213,11 @@ func NewServer(embedCtx context.Context, host, port string, handler http.Handler
 	}
 }
 
-type ReadinessHandler struct {
-	available *atomic.Bool
-	trace     trace.Tracer
-}
-
-func NewReadinessHandler(trace trace.Tracer) *ReadinessHandler {
-	available := atomic.Bool{}
-	available.Store(true)
-	return &ReadinessHandler{
-		available: &available,
-		trace:     trace,
-	}
-}
-
-func (r *ReadinessHandler) MakeUnavailable() {
-	r.available.Store(false)
-}
-
-func (r *ReadinessHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	ctx := req.Context()
-	ua := req.UserAgent()
-
-	_, span := r.trace.Start(ctx, "healthcheck", trace.WithAttributes(
-		semconv.UserAgentName(ua),
-	))
-	defer span.End()
-
-	switch r.available.Load() {
-	case true:
-		span.AddEvent("healthy")
-		http.Error(w, "OK", http.StatusOK)
-	case false:
-		span.AddEvent("unhealthy")
-		http.Error(w, "Shutting down", http.StatusServiceUnavailable)
-	}
-}
-
-func produceTraces(ctx context.Context, tracer trace.Tracer) {
+// produceTraces periodically exercises a real, otelhttp-instrumented
+// client call to the server's own /livez and, when a database is
+// configured, a real otelpgx-traced ping, so the "fetch" and "save"
+// legs of the dummy trace are genuine spans rather than simulated ones.
+func produceTraces(ctx context.Context, tracer trace.Tracer, httpClient *http.Client, selfURL string, pool *pgxpool.Pool) {
 	ticker := time.NewTicker(3 * time.Second)
 	defer ticker.Stop()
 
@@ -163,18 +229,27 @@ func produceTraces(ctx context.Context, tracer trace.Tracer) {
 			// Create a root span for a dummy operation
 			ctx, span := tracer.Start(ctx, "dummy.operation")
 
-			// Simulate some work with nested spans
+			// Fetch leg: a real instrumented HTTP call, not a simulation.
 			func() {
-				_, childSpan := tracer.Start(ctx, "dummy.fetch_data",
-					trace.WithAttributes(
-						semconv.HTTPRequestMethodGet,
-						semconv.HTTPResponseStatusCode(200),
-					),
+				childCtx, childSpan := tracer.Start(ctx, "dummy.fetch_data",
+					trace.WithAttributes(semconv.HTTPRequestMethodGet),
 				)
 				defer childSpan.End()
 
-				childSpan.AddEvent("fetching data from database")
-				time.Sleep(100 * time.Millisecond)
+				req, err := http.NewRequestWithContext(childCtx, http.MethodGet, selfURL, nil)
+				if err != nil {
+					tracing.RecordError(childSpan, err)
+					return
+				}
+
+				resp, err := httpClient.Do(req)
+				if err != nil {
+					tracing.RecordError(childSpan, err)
+					return
+				}
+				defer resp.Body.Close()
+
+				childSpan.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
 			}()
 
 			func() {
@@ -186,7 +261,7 @@ func produceTraces(ctx context.Context, tracer trace.Tracer) {
 			}()
 
 			func() {
-				_, childSpan := tracer.Start(ctx, "dummy.save_result",
+				childCtx, childSpan := tracer.Start(ctx, "dummy.save_result",
 					trace.WithAttributes(
 						semconv.DBSystemNamePostgreSQL,
 						semconv.DBOperationName("insert"),
@@ -194,8 +269,15 @@ func produceTraces(ctx context.Context, tracer trace.Tracer) {
 				)
 				defer childSpan.End()
 
-				childSpan.AddEvent("saving result to database")
-				time.Sleep(75 * time.Millisecond)
+				if pool == nil {
+					childSpan.AddEvent("no database configured, simulating save")
+					time.Sleep(75 * time.Millisecond)
+					return
+				}
+
+				if err := pool.Ping(childCtx); err != nil {
+					tracing.RecordError(childSpan, err)
+				}
 			}()
 
 			span.AddEvent("operation completed successfully")
@@ -1,13 +1,144 @@
 package config
 
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
 type AppConfig struct {
 	Environment Environment
+	Tracing     TracingConfig
+	Database    DatabaseConfig
 }
 
 type Environment string
 
-func New() AppConfig {
-	return AppConfig{
-		Environment: "development",
+const (
+	EnvironmentDevelopment Environment = "development"
+	EnvironmentProduction  Environment = "production"
+)
+
+// TracingExporterKind selects which span exporter tracing.Init builds.
+type TracingExporterKind string
+
+const (
+	TracingExporterOTLPHTTP TracingExporterKind = "otlphttp"
+	TracingExporterOTLPGRPC TracingExporterKind = "otlpgrpc"
+	TracingExporterStdout   TracingExporterKind = "stdout"
+	TracingExporterNone     TracingExporterKind = "none"
+)
+
+// Headers is a set of OTLP exporter headers. It implements envconfig's
+// Decoder so OTEL_EXPORTER_OTLP_HEADERS is parsed per the OTel spec
+// ("key=value,key2=value2"), rather than envconfig's default map syntax
+// ("key:value,key2:value2").
+type Headers map[string]string
+
+// Decode parses value as a comma-separated list of key=value pairs.
+func (h *Headers) Decode(value string) error {
+	headers := make(Headers)
+	if value == "" {
+		*h = headers
+		return nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("config: invalid header %q, want key=value", pair)
+		}
+
+		headers[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
 	}
+
+	*h = headers
+	return nil
+}
+
+// TracingConfig controls how the server exports and samples traces.
+// Field names mirror the OTEL_EXPORTER_OTLP_* env vars where one exists,
+// so a deployment can be pointed at a collector without code changes.
+type TracingConfig struct {
+	Exporter           TracingExporterKind `envconfig:"TRACING_EXPORTER" default:"otlphttp"`
+	Endpoint           string              `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	Insecure           bool                `envconfig:"OTEL_EXPORTER_OTLP_INSECURE" default:"false"`
+	Headers            Headers             `envconfig:"OTEL_EXPORTER_OTLP_HEADERS"`
+	SamplerRatio       float64             `envconfig:"TRACING_SAMPLER_RATIO" default:"1.0"`
+	BatchTimeout       time.Duration       `envconfig:"TRACING_BATCH_TIMEOUT" default:"5s"`
+	MaxQueueSize       int                 `envconfig:"TRACING_MAX_QUEUE_SIZE" default:"2048"`
+	MaxExportBatchSize int                 `envconfig:"TRACING_MAX_EXPORT_BATCH_SIZE" default:"512"`
+	ServiceName        string              `envconfig:"TRACING_SERVICE_NAME" default:"server"`
+	ServiceVersion     string              `envconfig:"TRACING_SERVICE_VERSION"`
+	Propagators        []string            `envconfig:"OTEL_PROPAGATORS" default:"tracecontext,baggage"`
+}
+
+// Validate reports whether the tracing configuration is usable, so
+// callers can fail fast at startup instead of panicking mid-init.
+func (c TracingConfig) Validate() error {
+	switch c.Exporter {
+	case TracingExporterOTLPHTTP, TracingExporterOTLPGRPC, TracingExporterStdout, TracingExporterNone:
+	default:
+		return fmt.Errorf("config: unknown tracing exporter %q", c.Exporter)
+	}
+
+	if c.SamplerRatio < 0 || c.SamplerRatio > 1 {
+		return fmt.Errorf("config: tracing sampler ratio must be within [0,1], got %v", c.SamplerRatio)
+	}
+
+	if c.Exporter == TracingExporterOTLPHTTP || c.Exporter == TracingExporterOTLPGRPC {
+		if c.Endpoint == "" {
+			return fmt.Errorf("config: tracing exporter %q requires an endpoint", c.Exporter)
+		}
+	}
+
+	for _, p := range c.Propagators {
+		switch p {
+		case "tracecontext", "baggage", "b3", "b3multi", "jaeger":
+		default:
+			return fmt.Errorf("config: unknown propagator %q", p)
+		}
+	}
+
+	return nil
+}
+
+// DatabaseConfig points at an optional database. DSN is empty by
+// default, meaning no database readiness probe is registered.
+type DatabaseConfig struct {
+	DSN string `envconfig:"DATABASE_URL"`
+}
+
+// New loads AppConfig from the environment and validates it, returning
+// an error rather than panicking so main can fail fast on bad config.
+func New() (AppConfig, error) {
+	cfg := AppConfig{
+		Environment: EnvironmentDevelopment,
+	}
+
+	if env := os.Getenv("ENVIRONMENT"); env != "" {
+		cfg.Environment = Environment(env)
+	}
+
+	if err := envconfig.Process("", &cfg.Tracing); err != nil {
+		return AppConfig{}, fmt.Errorf("config: load tracing config: %w", err)
+	}
+
+	if err := cfg.Tracing.Validate(); err != nil {
+		return AppConfig{}, err
+	}
+
+	if err := envconfig.Process("", &cfg.Database); err != nil {
+		return AppConfig{}, fmt.Errorf("config: load database config: %w", err)
+	}
+
+	return cfg, nil
 }
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHeadersDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Headers
+		wantErr bool
+	}{
+		{
+			name:  "empty value yields no headers",
+			value: "",
+			want:  Headers{},
+		},
+		{
+			name:  "single pair",
+			value: "api-key=abc123",
+			want:  Headers{"api-key": "abc123"},
+		},
+		{
+			name:  "multiple pairs trim surrounding whitespace",
+			value: "api-key=abc123, other = value",
+			want:  Headers{"api-key": "abc123", "other": "value"},
+		},
+		{
+			name:  "blank entries between commas are skipped",
+			value: "api-key=abc123,,other=value",
+			want:  Headers{"api-key": "abc123", "other": "value"},
+		},
+		{
+			name:    "missing equals sign is an error",
+			value:   "api-key",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var h Headers
+			err := h.Decode(tt.value)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Decode(%q) = nil error, want error", tt.value)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Decode(%q) = %v, want nil error", tt.value, err)
+			}
+			if !reflect.DeepEqual(h, tt.want) {
+				t.Errorf("Decode(%q) = %v, want %v", tt.value, h, tt.want)
+			}
+		})
+	}
+}
+
+func TestTracingConfigValidate(t *testing.T) {
+	base := func(mutate func(*TracingConfig)) TracingConfig {
+		cfg := TracingConfig{
+			Exporter:     TracingExporterOTLPHTTP,
+			Endpoint:     "localhost:4318",
+			SamplerRatio: 1.0,
+			Propagators:  []string{"tracecontext", "baggage"},
+		}
+		if mutate != nil {
+			mutate(&cfg)
+		}
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		cfg     TracingConfig
+		wantErr bool
+	}{
+		{
+			name: "otlphttp with endpoint is valid",
+			cfg:  base(nil),
+		},
+		{
+			name: "stdout exporter needs no endpoint",
+			cfg: base(func(c *TracingConfig) {
+				c.Exporter = TracingExporterStdout
+				c.Endpoint = ""
+			}),
+		},
+		{
+			name: "none exporter needs no endpoint",
+			cfg: base(func(c *TracingConfig) {
+				c.Exporter = TracingExporterNone
+				c.Endpoint = ""
+			}),
+		},
+		{
+			name: "unknown exporter is invalid",
+			cfg: base(func(c *TracingConfig) {
+				c.Exporter = "carrier-pigeon"
+			}),
+			wantErr: true,
+		},
+		{
+			name: "otlpgrpc without endpoint is invalid",
+			cfg: base(func(c *TracingConfig) {
+				c.Exporter = TracingExporterOTLPGRPC
+				c.Endpoint = ""
+			}),
+			wantErr: true,
+		},
+		{
+			name: "sampler ratio above 1 is invalid",
+			cfg: base(func(c *TracingConfig) {
+				c.SamplerRatio = 1.5
+			}),
+			wantErr: true,
+		},
+		{
+			name: "sampler ratio below 0 is invalid",
+			cfg: base(func(c *TracingConfig) {
+				c.SamplerRatio = -0.1
+			}),
+			wantErr: true,
+		},
+		{
+			name: "unknown propagator is invalid",
+			cfg: base(func(c *TracingConfig) {
+				c.Propagators = []string{"tracecontext", "carrier-pigeon"}
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr && err == nil {
+				t.Fatalf("Validate() = nil error, want error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("Validate() = %v, want nil error", err)
+			}
+		})
+	}
+}
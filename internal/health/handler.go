@@ -0,0 +1,107 @@
+package health
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LivenessHandler serves /livez: it runs only the registered liveness
+// probes and never considers MakeUnavailable, since a server draining
+// requests is still alive.
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		ctx, span := c.tracer.Start(ctx, "livez")
+		defer span.End()
+
+		results := c.runProbes(ctx, span, c.liveness)
+		writeResults(w, r, results, true)
+	})
+}
+
+// ReadinessHandler serves /readyz: it runs the registered readiness
+// probes and also fails if MakeUnavailable has been called, so traffic
+// stops before in-flight requests are drained during shutdown.
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		ctx, span := c.tracer.Start(ctx, "readyz")
+		defer span.End()
+
+		results := c.runProbes(ctx, span, c.readiness)
+		writeResults(w, r, results, c.available.Load())
+	})
+}
+
+// writeResults reports overall health as 200/503, plus a per-probe
+// breakdown when the request carries ?verbose=1.
+func writeResults(w http.ResponseWriter, r *http.Request, results []probeResult, available bool) {
+	healthy := available
+	for _, result := range results {
+		if !result.ok() {
+			healthy = false
+		}
+	}
+
+	status := http.StatusOK
+	if !healthy {
+		status = http.StatusServiceUnavailable
+	}
+
+	if r.URL.Query().Get("verbose") != "1" {
+		w.WriteHeader(status)
+		if healthy {
+			fmt.Fprintln(w, "OK")
+		} else {
+			fmt.Fprintln(w, "Unavailable")
+		}
+		return
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	var b strings.Builder
+	for _, result := range results {
+		verdict := "ok"
+		if !result.ok() {
+			verdict = fmt.Sprintf("failed: %v", result.Err)
+		}
+		fmt.Fprintf(&b, "[%s] %s (%s)\n", statusMark(result.ok()), result.Name, verdict)
+	}
+	if healthy {
+		fmt.Fprintln(&b, "status: OK")
+	} else {
+		fmt.Fprintln(&b, "status: Unavailable")
+	}
+
+	w.WriteHeader(status)
+	io.WriteString(w, b.String())
+}
+
+func statusMark(ok bool) string {
+	if ok {
+		return "+"
+	}
+	return "-"
+}
+
+// addProbeEvent records a span event for a single probe run, so a trace
+// of a /livez or /readyz call shows which probe (if any) failed and how
+// long each took.
+func addProbeEvent(span trace.Span, result probeResult) {
+	attrs := []attribute.KeyValue{
+		attribute.String("probe.name", result.Name),
+		attribute.Bool("probe.ok", result.ok()),
+		attribute.Int64("probe.duration_ms", result.Duration.Milliseconds()),
+	}
+	if !result.ok() {
+		attrs = append(attrs, attribute.String("probe.error", result.Err.Error()))
+	}
+	span.AddEvent("probe", trace.WithAttributes(attrs...))
+}
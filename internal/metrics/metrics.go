@@ -0,0 +1,35 @@
+// Package metrics stands up a Prometheus scrape endpoint backed by the
+// OTel metric SDK, so both auto-recorded RED metrics and any
+// business-level metric.Meter instruments land on the same /metrics
+// target.
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// Init registers an OTel-Prometheus bridge as the global MeterProvider
+// and returns a RED Recorder wired to it plus the promhttp.Handler to
+// serve at /metrics.
+func Init() (provider *sdkmetric.MeterProvider, recorder *Recorder, handler http.Handler, err error) {
+	exporter, err := otelprometheus.New()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("metrics: build prometheus exporter: %w", err)
+	}
+
+	provider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter))
+	otel.SetMeterProvider(provider)
+
+	recorder, err = newRecorder(provider.Meter("http.server"))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("metrics: build recorder: %w", err)
+	}
+
+	return provider, recorder, promhttp.Handler(), nil
+}
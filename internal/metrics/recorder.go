@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Recorder records RED (rate, errors, duration) metrics for every
+// request passed through middleware.Metrics. It satisfies the
+// middleware.Recorder interface.
+type Recorder struct {
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+func newRecorder(meter metric.Meter) (*Recorder, error) {
+	requests, err := meter.Int64Counter("http.server.request_count",
+		metric.WithDescription("Total number of HTTP requests handled"))
+	if err != nil {
+		return nil, err
+	}
+
+	errors, err := meter.Int64Counter("http.server.error_count",
+		metric.WithDescription("Total number of HTTP requests that returned a 5xx status"))
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram("http.server.duration",
+		metric.WithDescription("HTTP request duration"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{requests: requests, errors: errors, duration: duration}, nil
+}
+
+// ObserveRequest records one request's outcome, labeled by route,
+// method, and status.
+func (r *Recorder) ObserveRequest(route, method string, status int, duration time.Duration) {
+	ctx := context.Background()
+	attrs := metric.WithAttributes(
+		attribute.String("route", route),
+		attribute.String("method", method),
+		attribute.Int("status", status),
+	)
+
+	r.requests.Add(ctx, 1, attrs)
+	r.duration.Record(ctx, duration.Seconds(), attrs)
+
+	if status >= http.StatusInternalServerError {
+		r.errors.Add(ctx, 1, attrs)
+	}
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AccessLog emits a structured access log line per request, enriched
+// with the trace_id/span_id of the current span so logs and traces can
+// be correlated in whatever backend ingests them.
+func AccessLog(logger *slog.Logger) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			h.ServeHTTP(sw, r)
+
+			sc := trace.SpanContextFromContext(r.Context())
+			attrs := []any{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", sw.status),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if sc.HasTraceID() {
+				attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()))
+			}
+			if sc.HasSpanID() {
+				attrs = append(attrs, slog.String("span_id", sc.SpanID().String()))
+			}
+
+			logger.LogAttrs(r.Context(), slog.LevelInfo, "request handled", slog.Group("http", attrs...))
+		})
+	}
+}
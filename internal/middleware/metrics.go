@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+)
+
+// Recorder records RED (rate, errors, duration) metrics for a single
+// request. Implementations back it with whatever metrics backend the
+// caller wants (Prometheus, OTel metrics, ...).
+type Recorder interface {
+	ObserveRequest(route, method string, status int, duration time.Duration)
+}
+
+// Metrics records request count, error count, and latency for every
+// request handled by h, labeled by route, method, and status.
+func Metrics(recorder Recorder, route string) Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			h.ServeHTTP(sw, r)
+
+			recorder.ObserveRequest(route, r.Method, sw.status, time.Since(start))
+		})
+	}
+}
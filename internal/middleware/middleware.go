@@ -0,0 +1,31 @@
+// Package middleware provides a composable pipeline of http.Handler
+// decorators so every route gets the same observability and safety
+// behavior without per-handler boilerplate.
+package middleware
+
+import "net/http"
+
+// Middleware wraps an http.Handler with additional behavior.
+type Middleware func(http.Handler) http.Handler
+
+// Pipeline is an ordered list of Middleware, applied outermost-first.
+type Pipeline []Middleware
+
+// New builds a Pipeline from the given middleware, in the order they
+// should see the request (the first middleware runs first).
+func New(mw ...Middleware) Pipeline {
+	return Pipeline(mw)
+}
+
+// Then decorates h with every middleware in the pipeline.
+func (p Pipeline) Then(h http.Handler) http.Handler {
+	for i := len(p) - 1; i >= 0; i-- {
+		h = p[i](h)
+	}
+	return h
+}
+
+// ThenFunc is Then for an http.HandlerFunc.
+func (p Pipeline) ThenFunc(h http.HandlerFunc) http.Handler {
+	return p.Then(h)
+}
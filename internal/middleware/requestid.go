@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// _headerRequestID is the header used to propagate a request ID to and
+// from clients, independent of the W3C traceparent header.
+const _headerRequestID = "X-Request-Id"
+
+// RequestID sets an X-Request-Id response header. If the client supplied
+// one it is echoed back; otherwise it falls back to the trace ID of the
+// current span (populated by the tracing middleware that should run
+// before this one), so logs and clients can correlate on the same
+// identifier as traces do. When there is neither a client ID nor a trace
+// ID (e.g. tracing is disabled), no header is set.
+func RequestID() Middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(_headerRequestID)
+			if id == "" {
+				if span := trace.SpanContextFromContext(r.Context()); span.HasTraceID() {
+					id = span.TraceID().String()
+				}
+			}
+
+			if id != "" {
+				w.Header().Set(_headerRequestID, id)
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/XSAM/otelsql"
+	"github.com/exaring/otelpgx"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NewPGXPool opens a pgxpool.Pool with otelpgx tracing installed, so
+// every query run through the pool produces a span of its own.
+func NewPGXPool(ctx context.Context, dsn string) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: parse pgx config: %w", err)
+	}
+
+	poolCfg.ConnConfig.Tracer = otelpgx.NewTracer()
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: open pgx pool: %w", err)
+	}
+
+	return pool, nil
+}
+
+// NewSQLDB opens a *sql.DB through driverName with otelsql tracing
+// installed, for callers using database/sql instead of pgx directly.
+func NewSQLDB(driverName, dsn string) (*sql.DB, error) {
+	db, err := otelsql.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: open sql db: %w", err)
+	}
+
+	return db, nil
+}
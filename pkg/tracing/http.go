@@ -0,0 +1,16 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// NewHTTPClient returns an *http.Client whose RoundTripper is wrapped
+// with otelhttp, so every outbound request gets a client span and
+// propagates the current trace context to whatever it calls.
+func NewHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+	}
+}
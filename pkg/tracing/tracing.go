@@ -0,0 +1,169 @@
+// Package tracing bootstraps OpenTelemetry tracing for the server and
+// hands back ready-to-use, instrumented clients (HTTP, Postgres) so any
+// caller gets end-to-end traces across HTTP-in, HTTP-out, and SQL
+// without wiring OTel by hand.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Alzgaymer/k8s-test/internal/config"
+)
+
+// Init builds and installs the global TracerProvider and TextMapPropagator
+// described by cfg. Exporter selection, sampling, and batching are all
+// driven by cfg instead of being hardcoded, so prod can point at a
+// collector over gRPC while tests run with cfg.Exporter set to
+// config.TracingExporterNone.
+func Init(ctx context.Context, cfg config.TracingConfig) (tp trace.TracerProvider, shutdown func(context.Context) error, err error) {
+	prop, err := newPropagator(cfg.Propagators)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tracing: build propagator: %w", err)
+	}
+	otel.SetTextMapPropagator(prop)
+
+	if cfg.Exporter == config.TracingExporterNone {
+		slog.InfoContext(ctx, "tracing disabled")
+		noop := otel.GetTracerProvider()
+		return noop, func(context.Context) error { return nil }, nil
+	}
+
+	serviceVersion := cfg.ServiceVersion
+	if serviceVersion == "" {
+		serviceVersion = "dev"
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(cfg.ServiceName),
+			semconv.ServiceVersion(serviceVersion),
+			semconv.DeploymentEnvironmentName("dev"),
+		),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tracing: build resource: %w", err)
+	}
+
+	otel.SetErrorHandler(&slogErrorHandler{slog.Default()})
+
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tracing: build exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter,
+			sdktrace.WithBatchTimeout(cfg.BatchTimeout),
+			sdktrace.WithMaxQueueSize(cfg.MaxQueueSize),
+			sdktrace.WithMaxExportBatchSize(cfg.MaxExportBatchSize),
+		),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SamplerRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	slog.InfoContext(ctx, "otel tracer is configured", slog.String("exporter", string(cfg.Exporter)))
+
+	return provider, provider.Shutdown, nil
+}
+
+// Tracer is a convenience wrapper around the global TracerProvider, for
+// callers that only need a named tracer and don't hold onto the
+// TracerProvider returned by Init.
+func Tracer(name string) trace.Tracer {
+	return otel.GetTracerProvider().Tracer(name)
+}
+
+// RecordError marks span as errored and attaches err to it. It is a
+// no-op if err is nil.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// newExporter builds the span exporter selected by cfg.Exporter.
+func newExporter(ctx context.Context, cfg config.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case config.TracingExporterOTLPHTTP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+		}
+		return otlptracehttp.New(ctx, opts...)
+
+	case config.TracingExporterOTLPGRPC:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+		}
+		return otlptracegrpc.New(ctx, opts...)
+
+	case config.TracingExporterStdout:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+
+	default:
+		return nil, fmt.Errorf("tracing: unknown exporter %q", cfg.Exporter)
+	}
+}
+
+// newPropagator composes the TextMapPropagators named in names, in
+// order, so incoming traceparent/baggage (or B3/Jaeger) headers are
+// understood regardless of which upstream system sent them.
+func newPropagator(names []string) (propagation.TextMapPropagator, error) {
+	if len(names) == 0 {
+		return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}), nil
+	}
+
+	props := make([]propagation.TextMapPropagator, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case "tracecontext":
+			props = append(props, propagation.TraceContext{})
+		case "baggage":
+			props = append(props, propagation.Baggage{})
+		case "b3":
+			props = append(props, b3.New(b3.WithInjectEncoding(b3.B3SingleHeader)))
+		case "b3multi":
+			props = append(props, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+		case "jaeger":
+			props = append(props, jaeger.Jaeger{})
+		default:
+			return nil, fmt.Errorf("tracing: unknown propagator %q", name)
+		}
+	}
+
+	return propagation.NewCompositeTextMapPropagator(props...), nil
+}
+
+type slogErrorHandler struct {
+	log *slog.Logger
+}
+
+func (s *slogErrorHandler) Handle(err error) {
+	s.log.Error("Error in otel instrumentation", "err", err)
+}